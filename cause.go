@@ -0,0 +1,51 @@
+package errors
+
+// Cause walks the Unwrap chain of err and returns the deepest non-nil
+// error: the one with no further children. It coexists with errors.Is
+// and errors.As but gives call sites a single-line way to reach the
+// original failure, which is a recurring need in filesystem/RPC code
+// where an OS-level *PathError is wrapped several layers deep, e.g.
+//
+//	if os.IsNotExist(errors.Cause(err)) { ... }
+//
+// "Deepest" is inherently ambiguous for a multi-error aggregate such as
+// *Group (Unwrap() []error): there is no single root. When Cause meets
+// one, it follows the first error added (the one Combine or Add saw
+// first) rather than stopping at the aggregate, since that is the error
+// most call sites constructed the group around; the rest are reachable
+// via Find, FindType, errors.Is, or errors.As, or by inspecting
+// (*Group).Errors() directly.
+// If err is nil, Cause returns nil.
+func Cause(err error) error {
+	for err != nil {
+		children := unwrapChildren(err)
+		if len(children) == 0 {
+			return err
+		}
+		err = children[0]
+	}
+	return nil
+}
+
+// RootStackTrace returns the stack trace recorded by the deepest wrapper
+// in err's Unwrap chain that implements the stackTracer interface. This
+// lets %+v-style debug dumps surface the original call site even when an
+// intermediate Wrap, Wrapf or WithStack call recorded only the top frame
+// because the error it annotated already had a stack trace.
+//
+// As with Cause, RootStackTrace follows only the first child of a
+// multi-error aggregate such as *Group; it does not search the others.
+func RootStackTrace(err error) StackTrace {
+	var root StackTrace
+	for err != nil {
+		if st, ok := err.(stackTracer); ok {
+			root = st.StackTrace()
+		}
+		children := unwrapChildren(err)
+		if len(children) == 0 {
+			break
+		}
+		err = children[0]
+	}
+	return root
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"testing"
+)
+
+func TestCause(t *testing.T) {
+	if Cause(nil) != nil {
+		t.Fatalf("Cause(nil) should be nil")
+	}
+
+	root := New("root cause")
+	wrapped := Wrapf(Wrap(root, "middle"), "outer %d", 1)
+
+	if got := Cause(wrapped); got != root {
+		t.Fatalf("Cause returned %#v, want %#v", got, root)
+	}
+
+	plain := &noStackErr{msg: "no unwrap here"}
+	if got := Cause(plain); got != plain {
+		t.Fatalf("Cause(plain) = %#v, want err unchanged", got)
+	}
+}
+
+func TestCause_throughGroup(t *testing.T) {
+	first := New("first failure")
+	group := Combine(first, New("second failure"))
+
+	if got := Cause(group); got != first {
+		t.Fatalf("Cause(group) = %#v, want the first error added %#v", got, first)
+	}
+}
+
+func TestRootStackTrace(t *testing.T) {
+	root := New("root cause")
+	wrapped := Wrap(WithMessage(root, "annotation only"), "outer")
+
+	st := RootStackTrace(wrapped)
+	rootSt, ok := root.(stackTracer)
+	if !ok {
+		t.Fatalf("root should implement stackTracer")
+	}
+	if len(st) != len(rootSt.StackTrace()) {
+		t.Fatalf("RootStackTrace returned %d frames, want the root's %d", len(st), len(rootSt.StackTrace()))
+	}
+
+	if RootStackTrace(&noStackErr{msg: "no stack anywhere"}) != nil {
+		t.Fatalf("RootStackTrace should be nil when nothing in the chain has a stack")
+	}
+}
@@ -63,7 +63,6 @@
 package errors
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -112,34 +111,77 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 	}
 }
 
-type wrappedError struct {
-	err   error
-	msg   string
-	stack *stack
+// stackTracer is implemented by errors that record a stack trace, such as
+// those returned by New, Errorf and WithStack. The error returned by Wrap
+// and Wrapf does not implement it itself; the *withStack it wraps does.
+type stackTracer interface {
+	StackTrace() StackTrace
 }
 
-func (w *wrappedError) Error() string { return w.msg + ": " + w.err.Error() }
+// withStack annotates an error with a stack trace but no message.
+type withStack struct {
+	err error
+	*stack
+}
+
+func (w *withStack) Error() string { return w.err.Error() }
+
+func (w *withStack) Unwrap() error { return w.err }
 
-func (w *wrappedError) Format(s fmt.State, verb rune) {
+func (w *withStack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			io.WriteString(s, w.msg)
+			w.stack.Format(s, verb)
+			fmt.Fprintf(s, "\n%+v", w.err)
+			return
+		}
+		fallthrough
+	case 's', 'q':
+		io.WriteString(s, w.Error())
+	}
+}
 
-			isRoot := (errors.Unwrap(w.err) == nil)
-			_, isFormatter := w.err.(fmt.Formatter)
-			// Print the wrapped error message between the wrapping message and stack trace
-			// if the wrapped error is the root error and does not implement fmt.Formatter.
-			if isRoot && !isFormatter {
-				fmt.Fprintf(s, "\n%+v", w.err)
-				w.stack.Format(s, verb)
-				io.WriteString(s, "\n")
+// WithStack annotates err with a stack trace at the point WithStack is
+// called. If err already implements the stackTracer interface, only the
+// top frame is recorded, so repeated annotation of an already-stacked
+// error does not accumulate a second full stack trace.
+// If err is nil, WithStack returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(stackTracer); ok {
+		return &withStack{err: err, stack: topCaller()}
+	}
+
+	return &withStack{err: err, stack: callers()}
+}
+
+// withMessage annotates an error with a message but no stack trace.
+type withMessage struct {
+	err error
+	msg string
+}
+
+func (w *withMessage) Error() string { return w.msg + ": " + w.err.Error() }
+
+func (w *withMessage) Unwrap() error { return w.err }
+
+func (w *withMessage) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, w.msg)
+			// A *withStack child prints its own frame(s) first thing, via
+			// *stack.Format, so it supplies its own leading newline; any
+			// other child needs one inserted here to separate it from msg.
+			if _, ok := w.err.(*withStack); ok {
+				fmt.Fprintf(s, "%+v", w.err)
 			} else {
-				w.stack.Format(s, verb)
-				io.WriteString(s, "\n")
-				fmt.Fprintf(s, "%+v\n", w.err)
+				fmt.Fprintf(s, "\n%+v", w.err)
 			}
-
 			return
 		}
 		fallthrough
@@ -148,60 +190,68 @@ func (w *wrappedError) Format(s fmt.State, verb rune) {
 	}
 }
 
-func (w *wrappedError) Unwrap() error {
-	return w.err
+// WithMessage annotates err with the supplied message. Unlike WithStack,
+// WithMessage does not record a stack trace, so callers can annotate
+// errors crossing package boundaries without polluting %+v output with
+// intermediate frames.
+// If err is nil, WithMessage returns nil.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &withMessage{err: err, msg: message}
+}
+
+// WithMessagef annotates err with the format specifier. See WithMessage.
+// If err is nil, WithMessagef returns nil.
+func WithMessagef(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withMessage{err: err, msg: fmt.Sprintf(format, args...)}
 }
 
 // Wrap returns an error annotating err with a stack trace
 // at the point Wrap is called, and the supplied messages.
 // The messages are join into one message with "\n" separator.
 // If err is nil, Wrap returns nil.
+//
+// Wrap captures the stack inline rather than calling WithStack, so that
+// callers() and topCaller() see the same number of frames between
+// themselves and the caller of Wrap as they do between themselves and the
+// caller of WithStack; going through WithStack would insert an extra
+// frame pointing into this file instead of Wrap's caller.
 func Wrap(err error, messages ...string) error {
 	if err == nil {
 		return nil
 	}
 
 	message := strings.Join(messages, "\n")
-	_, ok := err.(fmt.Formatter)
-	// If err already implements fmt.Formatter, add only the top stack trace
-	if ok {
-		return &wrappedError{
-			err:   err,
-			msg:   message,
-			stack: topCaller(),
-		}
-	}
 
-	return &wrappedError{
-		err:   err,
-		msg:   message,
-		stack: callers(),
+	var ws *withStack
+	if _, ok := err.(stackTracer); ok {
+		ws = &withStack{err: err, stack: topCaller()}
+	} else {
+		ws = &withStack{err: err, stack: callers()}
 	}
+	return WithMessage(ws, message)
 }
 
 // Wrapf returns an error annotating err with a stack trace
 // at the point Wrapf is called, and the format specifier.
 // If err is nil, Wrapf returns nil.
+//
+// See Wrap for why the stack is captured inline instead of via WithStack.
 func Wrapf(err error, format string, args ...interface{}) error {
 	if err == nil {
 		return nil
 	}
 
-	message := fmt.Sprintf(format, args...)
-
-	_, ok := err.(fmt.Formatter)
-	// If err already implements fmt.Formatter, add only the top stack trace
-	if ok {
-		return &wrappedError{
-			err:   err,
-			msg:   message,
-			stack: topCaller(),
-		}
-	}
-
-	return &wrappedError{
-		err:   err,
-		msg:   message,
-		stack: callers(),
+	var ws *withStack
+	if _, ok := err.(stackTracer); ok {
+		ws = &withStack{err: err, stack: topCaller()}
+	} else {
+		ws = &withStack{err: err, stack: callers()}
 	}
+	return WithMessagef(ws, format, args...)
 }
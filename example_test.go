@@ -229,6 +229,34 @@ func ExampleErrorf_extended() {
 	//         /home/dfc/go/src/runtime/asm_amd64.s:2059
 }
 
+func ExampleCombine() {
+	err := errors.Combine(
+		nil,
+		errors.New("first failure"),
+		nil,
+		errors.New("second failure"),
+	)
+	fmt.Println(err)
+
+	// Output: 2 errors occurred: first failure; second failure
+}
+
+func ExampleCombine_single() {
+	err := errors.Combine(nil, errors.New("only failure"), nil)
+	fmt.Println(err)
+
+	// Output: only failure
+}
+
+func ExampleGroup_Add() {
+	g := errors.NewGroup()
+	g.Add(nil)
+	g.Add(errors.New("cleanup failed"))
+	fmt.Println(g.Errors())
+
+	// Output: [cleanup failed]
+}
+
 func ExampleCause_printf() {
 	err := errors.Wrap(func() error {
 		return func() error {
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"reflect"
+)
+
+// unwrapChildren returns the direct children of err: the result of
+// Unwrap() []error (e.g. a *Group), or a one-element slice holding the
+// result of Unwrap() error, or nil if err implements neither.
+func unwrapChildren(err error) []error {
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		return x.Unwrap()
+	case interface{ Unwrap() error }:
+		if u := x.Unwrap(); u != nil {
+			return []error{u}
+		}
+	}
+	return nil
+}
+
+// Find walks err and its Unwrap chain depth-first, calling match on each
+// error in turn, and returns the first one for which match reports true.
+// It returns nil if err is nil or no error matches. Find descends into
+// both single-error wrappers (Unwrap() error) and multi-error aggregates
+// such as *Group (Unwrap() []error), so a match buried inside a Combine
+// result is still reachable.
+//
+// Unlike errors.As, Find returns the wrapper node itself rather than
+// unwrapping into a target value. This lets callers implement typed
+// sentinels that carry their own stack trace, e.g. a controller wraps a
+// domain error with a RequeueError marker, and the outermost handler
+// recovers both the marker and its StackTrace():
+//
+//	if re := errors.FindType(err, (*RequeueError)(nil)); re != nil {
+//		requeue(re.(*RequeueError).After)
+//	}
+func Find(err error, match func(error) bool) error {
+	if err == nil {
+		return nil
+	}
+	if match(err) {
+		return err
+	}
+	for _, child := range unwrapChildren(err) {
+		if found := Find(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// FindType is a convenience wrapper around Find that matches by type
+// instead of a predicate. target is a typed nil pointer naming the type
+// to look for, e.g. (*RequeueError)(nil).
+func FindType(err error, target interface{}) error {
+	if target == nil {
+		return nil
+	}
+	targetType := reflect.TypeOf(target)
+	return Find(err, func(err error) bool {
+		return reflect.TypeOf(err) == targetType
+	})
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"testing"
+)
+
+type requeueError struct {
+	err   error
+	after int
+}
+
+func (e *requeueError) Error() string { return e.err.Error() }
+func (e *requeueError) Unwrap() error { return e.err }
+
+type thirdPartyError struct {
+	msg string
+}
+
+func (e *thirdPartyError) Error() string { return e.msg }
+
+func TestFind(t *testing.T) {
+	root := New("boom")
+	re := &requeueError{err: root, after: 5}
+	wrapped := Wrap(re, "reconcile failed")
+
+	found := Find(wrapped, func(err error) bool {
+		_, ok := err.(*requeueError)
+		return ok
+	})
+	if found != re {
+		t.Fatalf("Find returned %#v, want %#v", found, re)
+	}
+
+	if Find(wrapped, func(error) bool { return false }) != nil {
+		t.Fatalf("Find should return nil when nothing matches")
+	}
+
+	if Find(nil, func(error) bool { return true }) != nil {
+		t.Fatalf("Find(nil, ...) should return nil")
+	}
+}
+
+func TestFind_thirdParty(t *testing.T) {
+	tp := &thirdPartyError{msg: "rpc unavailable"}
+	wrapped := Wrapf(Wrap(tp, "dial"), "call %s", "Service.Method")
+
+	found := Find(wrapped, func(err error) bool {
+		_, ok := err.(*thirdPartyError)
+		return ok
+	})
+	if found != tp {
+		t.Fatalf("Find returned %#v, want %#v", found, tp)
+	}
+}
+
+func TestFind_throughGroup(t *testing.T) {
+	re := &requeueError{err: New("boom"), after: 5}
+	group := Combine(New("unrelated failure"), Wrap(re, "reconcile failed"))
+
+	found := Find(group, func(err error) bool {
+		_, ok := err.(*requeueError)
+		return ok
+	})
+	if found != re {
+		t.Fatalf("Find through a Group returned %#v, want %#v", found, re)
+	}
+
+	if found := FindType(group, (*requeueError)(nil)); found != re {
+		t.Fatalf("FindType through a Group returned %#v, want %#v", found, re)
+	}
+}
+
+func TestFindType(t *testing.T) {
+	root := New("boom")
+	re := &requeueError{err: root, after: 5}
+	wrapped := Wrap(re, "reconcile failed")
+
+	found := FindType(wrapped, (*requeueError)(nil))
+	if found != re {
+		t.Fatalf("FindType returned %#v, want %#v", found, re)
+	}
+
+	var missing *thirdPartyError
+	if FindType(wrapped, missing) != nil {
+		t.Fatalf("FindType should return nil for a type not present in the chain")
+	}
+}
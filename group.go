@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Group aggregates zero or more errors into a single error value. It
+// implements error and fmt.Formatter, and exposes its members through
+// Unwrap() []error so that errors.Is and errors.As can reach any one of
+// the errors it holds.
+//
+// Group is a natural companion to Wrap for fan-out code paths: deferred
+// cleanup, Close, or cancellation handlers that may fail in more than one
+// place and want to report every failure without losing any individual
+// stack trace.
+type Group struct {
+	errs  []error
+	stack *stack
+}
+
+// NewGroup returns an empty *Group, recording the stack trace at the
+// point it was called. Use Add to accumulate errors as they occur.
+func NewGroup() *Group {
+	return &Group{stack: callers()}
+}
+
+// Combine aggregates errs into a single error, dropping any nil values.
+// If none of errs is non-nil, Combine returns nil. If exactly one is
+// non-nil, that error is returned unchanged. Otherwise Combine returns a
+// *Group recording a stack trace at the point it was called.
+//
+// Combine captures the stack inline rather than calling NewGroup, so
+// that callers() sees the same number of frames between itself and
+// Combine's caller as it does between itself and NewGroup's caller;
+// going through NewGroup would insert an extra frame pointing into this
+// file instead of Combine's caller.
+func Combine(errs ...error) error {
+	g := &Group{stack: callers()}
+	for _, err := range errs {
+		g.Add(err)
+	}
+	return g.result()
+}
+
+// Add appends err to the group. Add is a no-op if err is nil, so call
+// sites can accumulate deferred errors unconditionally, e.g.
+//
+//	g := errors.NewGroup()
+//	defer func() { g.Add(f.Close()) }()
+func (g *Group) Add(err error) {
+	if err == nil {
+		return
+	}
+	g.errs = append(g.errs, err)
+}
+
+// Errors returns the errors accumulated in the group, in the order they
+// were added.
+func (g *Group) Errors() []error {
+	return g.errs
+}
+
+// result returns g as an error: nil if empty, the sole error if there is
+// only one, and g itself otherwise.
+func (g *Group) result() error {
+	switch len(g.errs) {
+	case 0:
+		return nil
+	case 1:
+		return g.errs[0]
+	default:
+		return g
+	}
+}
+
+// Unwrap returns the errors in the group so that errors.Is and errors.As
+// can reach any one of them.
+func (g *Group) Unwrap() []error {
+	return g.errs
+}
+
+func (g *Group) Error() string {
+	switch len(g.errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return g.errs[0].Error()
+	}
+	msgs := make([]string, len(g.errs))
+	for i, err := range g.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(g.errs), strings.Join(msgs, "; "))
+}
+
+func (g *Group) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%d errors occurred:", len(g.errs))
+			for _, err := range g.errs {
+				indented := strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n\t")
+				fmt.Fprintf(s, "\n\t%s", indented)
+			}
+			g.stack.Format(s, verb)
+			io.WriteString(s, "\n")
+			return
+		}
+		fallthrough
+	case 's', 'q':
+		io.WriteString(s, g.Error())
+	}
+}
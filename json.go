@@ -0,0 +1,244 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+)
+
+// jsonFrame is the wire representation of a single Frame.
+type jsonFrame struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding f as
+// {"func":"pkg.Fn","file":".../x.go","line":42}.
+func (f Frame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonFrame{
+		Func: f.name(),
+		File: f.file(),
+		Line: f.line(),
+	})
+}
+
+// MarshalJSON implements json.Marshaler, encoding f as
+// {"message":"...","stack":[{"func":...,"file":...,"line":...}, ...]}.
+func (f *fundamental) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message string      `json:"message"`
+		Stack   []jsonFrame `json:"stack,omitempty"`
+	}{
+		Message: f.msg,
+		Stack:   frameList(f.stack),
+	})
+}
+
+// MarshalJSON implements json.Marshaler, encoding w as
+// {"cause":{...},"stack":[...]}, with "cause" nested as w.err's own JSON
+// (via MarshalJSON) rather than merged into it, so w.err's own stack, if
+// any, is preserved alongside w's. w contributes no "message" field of
+// its own: it has no message, only a stack trace, so Unmarshal treats a
+// node with no "message" key as transparent and reports its cause's
+// message unchanged.
+func (w *withStack) MarshalJSON() ([]byte, error) {
+	cause, err := MarshalJSON(w.err)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Cause json.RawMessage `json:"cause,omitempty"`
+		Stack []jsonFrame     `json:"stack,omitempty"`
+	}{
+		Cause: cause,
+		Stack: frameList(w.stack),
+	})
+}
+
+// MarshalJSON implements json.Marshaler, encoding w as
+// {"message":"...","stack":[...],"cause":{...}}. Wrap and Wrapf always
+// produce a *withMessage directly wrapping a *withStack, the split halves
+// of what a single Wrap call conceptually is, so that pairing is merged
+// back into one flat object here: w's message and the withStack's own
+// frames, with "cause" holding the withStack's child (via MarshalJSON),
+// rather than emitting two separately nested JSON objects for one call
+// site. Any other child is nested under "cause" as a sibling object
+// instead, recursing via MarshalJSON.
+func (w *withMessage) MarshalJSON() ([]byte, error) {
+	if ws, ok := w.err.(*withStack); ok {
+		cause, err := MarshalJSON(ws.err)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(struct {
+			Message string          `json:"message"`
+			Stack   []jsonFrame     `json:"stack,omitempty"`
+			Cause   json.RawMessage `json:"cause,omitempty"`
+		}{
+			Message: w.msg,
+			Stack:   frameList(ws.stack),
+			Cause:   cause,
+		})
+	}
+
+	cause, err := MarshalJSON(w.err)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Message string          `json:"message"`
+		Cause   json.RawMessage `json:"cause,omitempty"`
+	}{
+		Message: w.msg,
+		Cause:   cause,
+	})
+}
+
+// MarshalJSON implements json.Marshaler, encoding g as
+// {"message":"...","causes":[...]}, with "causes" holding each
+// accumulated error's own JSON (via MarshalJSON, walking g's children
+// through unwrapChildren the same way Find and Cause do) so a reader
+// recovers every member's message, stack and cause chain instead of only
+// g.Error()'s flattened summary.
+func (g *Group) MarshalJSON() ([]byte, error) {
+	children := unwrapChildren(g)
+	causes := make([]json.RawMessage, len(children))
+	for i, err := range children {
+		raw, merr := MarshalJSON(err)
+		if merr != nil {
+			return nil, merr
+		}
+		causes[i] = raw
+	}
+	return json.Marshal(struct {
+		Message string            `json:"message"`
+		Causes  []json.RawMessage `json:"causes,omitempty"`
+	}{
+		Message: g.Error(),
+		Causes:  causes,
+	})
+}
+
+func frameList(s *stack) []jsonFrame {
+	st := s.StackTrace()
+	frames := make([]jsonFrame, len(st))
+	for i, f := range st {
+		frames[i] = jsonFrame{Func: f.name(), File: f.file(), Line: f.line()}
+	}
+	return frames
+}
+
+// MarshalJSON encodes err as a JSON object of the shape
+// {"message":"...","cause":{...},"stack":[{"func":"pkg.Fn","file":"...","line":42}, ...]},
+// walking err's Unwrap chain to populate "cause". Errors that implement
+// json.Marshaler themselves (fundamental, withStack, withMessage) are
+// marshaled via their own MarshalJSON; any other error in the chain
+// contributes only its message.
+func MarshalJSON(err error) ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+	if m, ok := err.(json.Marshaler); ok {
+		return m.MarshalJSON()
+	}
+	data := struct {
+		Message string          `json:"message"`
+		Cause   json.RawMessage `json:"cause,omitempty"`
+	}{
+		Message: err.Error(),
+	}
+	if cause := stderrors.Unwrap(err); cause != nil {
+		raw, merr := MarshalJSON(cause)
+		if merr != nil {
+			return nil, merr
+		}
+		data.Cause = raw
+	}
+	return json.Marshal(data)
+}
+
+// jsonErr is a read-only error reconstructed by Unmarshal. It preserves
+// the message, cause chain and frame strings captured by MarshalJSON, but
+// its frames carry no live program counters: it is meant for a log
+// shipper replaying an error between services, not for raising again.
+type jsonErr struct {
+	hasMessage bool // a "message" key was present, even if its value is ""
+	message    string
+	cause      error
+	frames     []jsonFrame
+}
+
+// Error recurses into e.cause the same way withMessage.Error() does, so
+// round-tripping through MarshalJSON/Unmarshal does not lose the cause's
+// contribution to the message. A node with no "message" key of its own
+// (e.g. one produced by withStack, which only adds a stack trace) is
+// transparent and reports its cause's message unchanged.
+func (e *jsonErr) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.message
+	case !e.hasMessage:
+		return e.cause.Error()
+	default:
+		return e.message + ": " + e.cause.Error()
+	}
+}
+
+func (e *jsonErr) Unwrap() error { return e.cause }
+
+func (e *jsonErr) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if e.hasMessage {
+				io.WriteString(s, e.message)
+			}
+			for _, f := range e.frames {
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", f.Func, f.File, f.Line)
+			}
+			if e.cause != nil {
+				fmt.Fprintf(s, "\n%+v", e.cause)
+			}
+			return
+		}
+		fallthrough
+	case 's', 'q':
+		io.WriteString(s, e.Error())
+	}
+}
+
+// Unmarshal reconstructs an error from JSON produced by MarshalJSON. The
+// result implements error and fmt.Formatter, and its Unwrap chain has one
+// link per nested "cause" object, so a Wrap or Wrapf call, which
+// MarshalJSON writes as one object, comes back as one link. It carries no
+// live stack frames. A "causes" list, as written for a *Group, is not
+// decoded: the result keeps only the group's message.
+func Unmarshal(data []byte) (error, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	e := &jsonErr{}
+	if m, ok := raw["message"]; ok {
+		if err := json.Unmarshal(m, &e.message); err != nil {
+			return nil, err
+		}
+		e.hasMessage = true
+	}
+	if s, ok := raw["stack"]; ok {
+		if err := json.Unmarshal(s, &e.frames); err != nil {
+			return nil, err
+		}
+	}
+	if c, ok := raw["cause"]; ok && len(c) > 0 {
+		cause, err := Unmarshal(c)
+		if err != nil {
+			return nil, err
+		}
+		e.cause = cause
+	}
+	return e, nil
+}
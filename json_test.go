@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type jsonNode struct {
+	Message string          `json:"message"`
+	Stack   []jsonFrame     `json:"stack"`
+	Cause   json.RawMessage `json:"cause"`
+}
+
+func TestMarshalJSON(t *testing.T) {
+	err := Wrap(New("root cause"), "middle", "outer")
+
+	data, merr := MarshalJSON(err)
+	if merr != nil {
+		t.Fatalf("MarshalJSON: %v", merr)
+	}
+
+	// A single Wrap call is one flat {"message","stack","cause"} object:
+	// the message and frame(s) recorded at that Wrap call site, not two
+	// separately nested nodes for its withMessage/withStack halves.
+	var outer jsonNode
+	if err := json.Unmarshal(data, &outer); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if outer.Message != "middle\nouter" {
+		t.Fatalf("outer.Message = %q, want %q", outer.Message, "middle\nouter")
+	}
+	if len(outer.Stack) == 0 {
+		t.Fatalf("outer has no stack: %s", data)
+	}
+	if len(outer.Cause) == 0 {
+		t.Fatalf("outer has no cause: %s", data)
+	}
+
+	var root jsonNode
+	if err := json.Unmarshal(outer.Cause, &root); err != nil {
+		t.Fatalf("json.Unmarshal(cause): %v", err)
+	}
+	if root.Message != "root cause" {
+		t.Fatalf("root.Message = %q, want %q", root.Message, "root cause")
+	}
+	if len(root.Stack) == 0 {
+		t.Fatalf("New's own stack was not nested under its own {\"message\":\"root cause\"} node: %s", data)
+	}
+	if len(root.Cause) != 0 {
+		t.Fatalf("root should have no further cause, got %s", root.Cause)
+	}
+
+	restored, uerr := Unmarshal(data)
+	if uerr != nil {
+		t.Fatalf("Unmarshal: %v", uerr)
+	}
+	if restored.Error() != err.Error() {
+		t.Fatalf("restored.Error() = %q, want %q", restored.Error(), err.Error())
+	}
+}
+
+func TestMarshalJSON_group(t *testing.T) {
+	group := Combine(New("a"), Wrap(New("root cause"), "b"))
+
+	data, merr := MarshalJSON(group)
+	if merr != nil {
+		t.Fatalf("MarshalJSON: %v", merr)
+	}
+
+	var node struct {
+		Message string            `json:"message"`
+		Causes  []json.RawMessage `json:"causes"`
+	}
+	if err := json.Unmarshal(data, &node); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if len(node.Causes) != 2 {
+		t.Fatalf("got %d causes, want 2", len(node.Causes))
+	}
+
+	var first struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(node.Causes[0], &first); err != nil {
+		t.Fatalf("decode causes[0]: %v", err)
+	}
+	if first.Message != "a" {
+		t.Fatalf("causes[0].message = %q, want %q", first.Message, "a")
+	}
+
+	var second struct {
+		Message string          `json:"message"`
+		Cause   json.RawMessage `json:"cause"`
+	}
+	if err := json.Unmarshal(node.Causes[1], &second); err != nil {
+		t.Fatalf("decode causes[1]: %v", err)
+	}
+	if second.Message != "b" {
+		t.Fatalf("causes[1].message = %q, want %q", second.Message, "b")
+	}
+	if len(second.Cause) == 0 {
+		t.Fatalf("causes[1] has no nested cause for its wrapped root error")
+	}
+}
+
+func TestMarshalUnmarshalJSON_roundTrip(t *testing.T) {
+	err := Wrap(New("root cause"), "annotated")
+
+	data, merr := MarshalJSON(err)
+	if merr != nil {
+		t.Fatalf("MarshalJSON: %v", merr)
+	}
+
+	restored, uerr := Unmarshal(data)
+	if uerr != nil {
+		t.Fatalf("Unmarshal: %v", uerr)
+	}
+	if restored.Error() != err.Error() {
+		t.Fatalf("restored.Error() = %q, want %q", restored.Error(), err.Error())
+	}
+}
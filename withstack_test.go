@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"testing"
+)
+
+type noStackErr struct{ msg string }
+
+func (e *noStackErr) Error() string { return e.msg }
+
+func TestWithStack(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Fatalf("WithStack(nil) should be nil")
+	}
+
+	plain := &noStackErr{msg: "boom"}
+	withNoStack := WithStack(plain)
+	st, ok := withNoStack.(stackTracer)
+	if !ok {
+		t.Fatalf("WithStack(no-stack error) should implement stackTracer")
+	}
+	full := st.StackTrace()
+	if len(full) < 2 {
+		t.Fatalf("WithStack(no-stack error) recorded %d frames, want a full stack", len(full))
+	}
+
+	hasStack := New("already stacked")
+	wrapped := WithStack(hasStack)
+	st2, ok := wrapped.(stackTracer)
+	if !ok {
+		t.Fatalf("WithStack(has-stack error) should implement stackTracer")
+	}
+	top := st2.StackTrace()
+	if len(top) != 1 {
+		t.Fatalf("WithStack(has-stack error) recorded %d frames, want only the top frame", len(top))
+	}
+}
+
+func TestWithMessage(t *testing.T) {
+	if WithMessage(nil, "x") != nil {
+		t.Fatalf("WithMessage(nil, ...) should be nil")
+	}
+
+	cause := New("boom")
+
+	withMsg := WithMessage(cause, "context")
+	if withMsg.Error() != "context: boom" {
+		t.Fatalf("Error() = %q, want %q", withMsg.Error(), "context: boom")
+	}
+	if _, ok := withMsg.(stackTracer); ok {
+		t.Fatalf("WithMessage should not record its own stack trace")
+	}
+
+	withEmptyMsg := WithMessage(cause, "")
+	if withEmptyMsg.Error() != ": boom" {
+		t.Fatalf("Error() = %q, want %q", withEmptyMsg.Error(), ": boom")
+	}
+}
+
+func TestWithStackAndMessageCombinations(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"no stack, no message", WithStack(&noStackErr{msg: "boom"}), "boom"},
+		{"no stack, with message", WithMessage(WithStack(&noStackErr{msg: "boom"}), "ctx"), "ctx: boom"},
+		{"has stack, no message", WithStack(New("boom")), "boom"},
+		{"has stack, with message", WithMessage(WithStack(New("boom")), "ctx"), "ctx: boom"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("%s: Error() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}